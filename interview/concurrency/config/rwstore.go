@@ -0,0 +1,24 @@
+package config
+
+import "sync"
+
+// RWStore is the RWMutex-guarded equivalent of Store, kept around so
+// ReadScalabilityReport has something to compare Store against: it is
+// the pattern most people reach for first, and the one Store exists
+// to replace for read-heavy workloads.
+type RWStore[T any] struct {
+	mu  sync.RWMutex
+	val *T
+}
+
+func (s *RWStore[T]) Load() *T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.val
+}
+
+func (s *RWStore[T]) Store(v *T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.val = v
+}
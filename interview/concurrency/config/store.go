@@ -0,0 +1,52 @@
+// Package config demonstrates the lock-free pattern that the fixed
+// Once in interview/concurrency/main.go motivates: shared, rarely
+// written, frequently read state should go through atomic.Pointer
+// rather than a mutex, because a reader never has to wait on a writer
+// (or on other readers) at all.
+package config
+
+import "sync/atomic"
+
+// Store holds a hot-reloadable value of type T. The zero value has no
+// current value; Load returns nil until the first Store.
+type Store[T any] struct {
+	val  atomic.Pointer[T]
+	subs atomic.Pointer[[]func(old, new *T)]
+}
+
+// Load returns the current value, or nil if Store has never been
+// called.
+func (s *Store[T]) Load() *T {
+	return s.val.Load()
+}
+
+// Store installs v as the current value and fans it out to every
+// subscriber with the value it replaces.
+func (s *Store[T]) Store(v *T) {
+	old := s.val.Swap(v)
+	if subs := s.subs.Load(); subs != nil {
+		for _, fn := range *subs {
+			fn(old, v)
+		}
+	}
+}
+
+// Subscribe registers fn to be called, with the old and new value,
+// every time Store is called after Subscribe returns. Subscribe is
+// safe to call concurrently with Store and with other Subscribe
+// calls: the subscriber list is copy-on-write, so Store always sees a
+// fully-formed slice and never blocks on a Subscribe in progress.
+func (s *Store[T]) Subscribe(fn func(old, new *T)) {
+	for {
+		old := s.subs.Load()
+		var next []func(old, new *T)
+		if old != nil {
+			next = make([]func(old, new *T), len(*old), len(*old)+1)
+			copy(next, *old)
+		}
+		next = append(next, fn)
+		if s.subs.CompareAndSwap(old, &next) {
+			return
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"runtime"
 	"sync"
@@ -8,15 +9,22 @@ import (
 	"time"
 
 	"bytes"
+
+	"go-leetcode/interview/concurrency/config"
+	"go-leetcode/interview/concurrency/syncx"
 )
 
 func main() {
 	// test1()
 	// test2()
 	// test3()
+	// test4()
 	// test5()
 	// test6()
-	test10()
+	// test6Bounded()
+	// test10()
+	// test12()
+	test11()
 }
 
 // 1 Mutex
@@ -116,31 +124,64 @@ func test3() {
 // atomic better for the simple operations
 
 type Once struct {
-	m    sync.Mutex
 	done uint32
+	m    sync.Mutex
 }
 
+// Do runs f exactly once, no matter how many goroutines call Do
+// concurrently, and blocks every caller until that one run of f has
+// returned - the same contract as sync.Once.Do.
+//
+// The earlier version of this type read o.done with
+// atomic.LoadUint32 on the fast path but then read/wrote it as a
+// plain field under o.m on the slow path - mixing atomic and
+// non-atomic accesses to the same word is a data race even though the
+// mutex made the slow path itself safe. A version after that switched
+// to atomic.CompareAndSwapUint32 alone and dropped the mutex, which
+// fixed the race but broke a different guarantee: it set o.done
+// before f ran, so a second caller could see done==1 and return while
+// f was still running, observing a half-initialized result. o.done is
+// only ever set once f has returned, under o.m, so a concurrent
+// caller that still sees done==0 blocks on the mutex until f is done.
 func (o *Once) Do(f func()) {
 	if atomic.LoadUint32(&o.done) == 1 {
 		return
 	}
+	o.doSlow(f)
+}
 
+func (o *Once) doSlow(f func()) {
 	o.m.Lock()
 	defer o.m.Unlock()
-
-	/*
-		correct version and not need to use Lock here
-		if atomic.CompareAndSwapUint32(&o.done, 0, 1) {
-			f()
-		}
-	*/
-
 	if o.done == 0 {
-		o.done = 1
+		defer atomic.StoreUint32(&o.done, 1)
 		f()
 	}
 }
 
+// test4 demonstrates config.Store, the lock-free pattern behind the
+// Once fix above: a reader never takes a lock, so read throughput
+// keeps scaling with core count instead of flattening out under
+// sync.RWMutex's RLock/Lock fairness.
+func test4() {
+	var s config.Store[string]
+	s.Subscribe(func(old, new *string) {
+		oldVal := "<none>"
+		if old != nil {
+			oldVal = *old
+		}
+		fmt.Printf("config changed: %s -> %s\n", oldVal, *new)
+	})
+
+	v := "initial"
+	s.Store(&v)
+	v2 := "reloaded"
+	s.Store(&v2)
+
+	fmt.Println("current config:", *s.Load())
+	fmt.Println(config.ReadScalabilityReport([]int{1, 10, 100, 1000}, 200*time.Millisecond))
+}
+
 // ==========================================================================================================
 // 5 Mutex
 // deadlock issue
@@ -204,9 +245,56 @@ func test6() {
 	}
 
 	var stats runtime.MemStats
-	for i := 0; ; i++ {
+	for i := 0; i < 5; i++ {
 		runtime.ReadMemStats(&stats)
-		fmt.Printf("Cycle %d: %dB\n", i, stats.Alloc)
+		fmt.Printf("sync.Pool cycle %d: %dB\n", i, stats.Alloc)
+		time.Sleep(time.Second)
+		runtime.GC()
+	}
+}
+
+// test6Bounded runs the same mixed 256MiB/1KiB workload as test6, but
+// through a syncx.BoundedPool sharded by size class instead of
+// sync.Pool, to show memory staying steady instead of ballooning to
+// one 256MiB buffer retained per P.
+func test6Bounded() {
+	pool := syncx.NewBoundedPool(syncx.BoundedPoolConfig[*bytes.Buffer]{
+		New: func(hint int) *bytes.Buffer {
+			b := new(bytes.Buffer)
+			b.Grow(hint)
+			return b
+		},
+		Capacity:    4,
+		MaxItemCost: 1 << 20, // 1MiB: the 256MiB buffer never enters the pool at all
+		Shards:      []int{4 << 10, 1 << 20},
+	})
+
+	processRequest := func(size int) {
+		b := pool.Get(size)
+		time.Sleep(500 * time.Millisecond)
+		b.Grow(size)
+		pool.Put(b, b.Cap())
+		time.Sleep(1 * time.Microsecond)
+	}
+
+	go func() {
+		for {
+			processRequest(1 << 28) // 256MiB, dropped instead of pooled
+		}
+	}()
+
+	for i := 0; i < 100; i++ {
+		go func() {
+			for {
+				processRequest(1 << 10) // 1KiB
+			}
+		}()
+	}
+
+	var stats runtime.MemStats
+	for i := 0; i < 5; i++ {
+		runtime.ReadMemStats(&stats)
+		fmt.Printf("BoundedPool cycle %d: %dB %+v\n", i, stats.Alloc, pool.Stats())
 		time.Sleep(time.Second)
 		runtime.GC()
 	}
@@ -295,3 +383,97 @@ func fn(wg *sync.WaitGroup, c chan int, a *int) {
 	*a = 1
 	<-c
 }
+
+// ==========================================================================================================
+// 11 syncx.Mutex.LockContext
+//
+// Degraded-cached-response pattern: a handler needs an expensive,
+// mutex-guarded computation, but would rather serve a slightly stale
+// cached value than blow the caller's deadline waiting for the lock.
+// This mirrors the request-with-deadline RPC discussion - the lock
+// acquisition itself respects ctx, instead of the handler either
+// blocking forever or polling TryLock in a loop.
+func test11() {
+	var mu syncx.Mutex
+	var expensive string
+	cached := "stale-but-fast result from the last successful computation"
+
+	compute := func() string {
+		mu.Lock()
+		defer mu.Unlock()
+		time.Sleep(2 * time.Second) // stands in for an expensive recomputation
+		expensive = "freshly computed result"
+		return expensive
+	}
+
+	handle := func(ctx context.Context) string {
+		if err := mu.LockContext(ctx); err != nil {
+			// deadline hit before we got the lock: degrade instead of blocking
+			return cached
+		}
+		defer mu.Unlock()
+		if expensive == "" {
+			expensive = "freshly computed result"
+		}
+		return expensive
+	}
+
+	go compute()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	fmt.Println(handle(ctx))
+}
+
+// ==========================================================================================================
+// 12 syncx.KeyedExecutor
+//
+// An interleaved (key, value) stream - like per-partition processing
+// of a Kafka-style topic - where values for the same key must be
+// applied in submission order but different keys should make progress
+// independently. Submit gives each key its own FIFO without the
+// caller managing any per-key goroutines itself.
+func test12() {
+	exec := syncx.NewKeyedExecutor(4)
+
+	type event struct {
+		key   string
+		value int
+	}
+	keys := []string{"a", "b", "c"}
+	var events []event
+	for i := 0; i < 20; i++ {
+		events = append(events, event{keys[i%len(keys)], i})
+	}
+
+	var mu sync.Mutex
+	results := map[string][]int{}
+	var dones []<-chan struct{}
+
+	for _, ev := range events {
+		ev := ev
+		dones = append(dones, exec.Submit(ev.key, func() {
+			time.Sleep(time.Duration(ev.value%3) * time.Millisecond) // jitter to prove order isn't accidental
+			mu.Lock()
+			results[ev.key] = append(results[ev.key], ev.value)
+			mu.Unlock()
+		}))
+	}
+
+	for _, d := range dones {
+		<-d
+	}
+
+	for _, k := range keys {
+		vals := results[k]
+		for i := 1; i < len(vals); i++ {
+			if vals[i] < vals[i-1] {
+				panic(fmt.Sprintf("syncx: KeyedExecutor reordered key %q: %v", k, vals))
+			}
+		}
+		fmt.Printf("key %q processed in order: %v\n", k, vals)
+	}
+
+	fmt.Println(syncx.KeyedThroughputReport(1000, 20, 8))
+}
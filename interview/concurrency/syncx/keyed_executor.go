@@ -0,0 +1,158 @@
+package syncx
+
+import "sync"
+
+// keyQueue is a singly-linked FIFO of tasks submitted for one key.
+// The same worker goroutine drains a keyQueue front-to-back, which is
+// what gives Submit its per-key ordering guarantee.
+type keyQueue struct {
+	ownerKey string // the map key this queue is filed under
+
+	mu        sync.Mutex
+	head      *keyTask
+	tail      *keyTask
+	pending   int  // tasks queued or currently running for this key
+	scheduled bool // true while some worker owns (or is about to own) this queue
+	retired   bool // true once removed from KeyedExecutor.queues
+}
+
+type keyTask struct {
+	fn   func()
+	done chan struct{}
+	next *keyTask
+}
+
+// KeyedExecutor runs submitted work serially and in submission order
+// per key, while different keys run concurrently across a fixed
+// worker pool.
+type KeyedExecutor struct {
+	shardsMu sync.RWMutex
+	queues   map[string]*keyQueue
+
+	ready chan *keyQueue
+}
+
+// NewKeyedExecutor starts workers goroutines that together service
+// every key submitted to the returned executor.
+func NewKeyedExecutor(workers int) *KeyedExecutor {
+	if workers < 1 {
+		workers = 1
+	}
+	e := &KeyedExecutor{
+		queues: make(map[string]*keyQueue),
+		ready:  make(chan *keyQueue, workers),
+	}
+	for i := 0; i < workers; i++ {
+		go e.worker()
+	}
+	return e
+}
+
+func (e *KeyedExecutor) worker() {
+	for q := range e.ready {
+		e.drain(q)
+	}
+}
+
+// drain runs every task in q, in order, on the calling worker. While
+// q.scheduled is true no other worker will touch q - Submit only
+// pushes q onto the ready channel on the transition from unscheduled
+// to scheduled - so this loop is the sole reader/writer of q.head
+// until it gives that ownership back up.
+//
+// head==nil is not by itself a safe retirement signal: it is also
+// true for the brief window between popping the last task and that
+// task's fn() finishing, and a naive retire-on-empty there would let
+// a second worker pick the same key up concurrently, breaking the
+// one-key-one-worker-at-a-time guarantee Submit promises. Ownership
+// (scheduled) and outstanding work (pending) are tracked and checked
+// separately for exactly that reason.
+func (e *KeyedExecutor) drain(q *keyQueue) {
+	for {
+		q.mu.Lock()
+		t := q.head
+		if t == nil {
+			// Nothing queued right now: give up ownership. If no task
+			// is in flight either, this key is genuinely idle and its
+			// queue can be retired from the shard map.
+			q.scheduled = false
+			if q.pending == 0 {
+				e.shardsMu.Lock()
+				delete(e.queues, q.ownerKey)
+				e.shardsMu.Unlock()
+				q.retired = true
+			}
+			q.mu.Unlock()
+			return
+		}
+		q.head = t.next
+		if q.head == nil {
+			q.tail = nil
+		}
+		q.mu.Unlock()
+
+		t.fn()
+		q.mu.Lock()
+		q.pending--
+		q.mu.Unlock()
+		close(t.done)
+	}
+}
+
+func (e *KeyedExecutor) getOrCreateQueue(key string) *keyQueue {
+	e.shardsMu.RLock()
+	q, ok := e.queues[key]
+	e.shardsMu.RUnlock()
+	if ok {
+		return q
+	}
+
+	e.shardsMu.Lock()
+	q, ok = e.queues[key]
+	if !ok {
+		q = &keyQueue{ownerKey: key}
+		e.queues[key] = q
+	}
+	e.shardsMu.Unlock()
+	return q
+}
+
+// Submit enqueues fn to run under key, returning a channel that is
+// closed once fn has finished running. Calls for the same key run
+// strictly in submission order; calls for different keys run
+// concurrently across the executor's worker pool.
+func (e *KeyedExecutor) Submit(key string, fn func()) <-chan struct{} {
+	done := make(chan struct{})
+	t := &keyTask{fn: fn, done: done}
+
+	for {
+		q := e.getOrCreateQueue(key)
+
+		q.mu.Lock()
+		if q.retired {
+			// q was drained and removed from the map between our
+			// lookup and this lock; get/create a fresh queue for key.
+			q.mu.Unlock()
+			continue
+		}
+
+		if q.head == nil {
+			q.head, q.tail = t, t
+		} else {
+			q.tail.next = t
+			q.tail = t
+		}
+		q.pending++
+
+		needsDispatch := !q.scheduled
+		if needsDispatch {
+			q.scheduled = true
+		}
+		q.mu.Unlock()
+
+		if needsDispatch {
+			e.ready <- q
+		}
+		return done
+	}
+}
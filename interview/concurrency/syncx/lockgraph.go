@@ -0,0 +1,199 @@
+package syncx
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// lockID identifies a Mutex/RWMutex for the lifetime of the process.
+// IDs are assigned lazily on first Lock, so a lock that is never used
+// never touches the graph.
+type lockID uint64
+
+var nextLockID uint64
+
+func newLockID() lockID {
+	return lockID(atomic.AddUint64(&nextLockID, 1))
+}
+
+// heldLock is one entry in a goroutine's lock stack.
+type heldLock struct {
+	id    lockID
+	stack []byte // stack at the moment this lock was acquired
+}
+
+// goroutineState tracks the locks currently held by one goroutine.
+type goroutineState struct {
+	held []heldLock
+}
+
+var (
+	statesMu sync.Mutex
+	states   = map[int64]*goroutineState{}
+)
+
+func currentState() *goroutineState {
+	gid := goroutineID()
+	statesMu.Lock()
+	defer statesMu.Unlock()
+	s := states[gid]
+	if s == nil {
+		s = &goroutineState{}
+		states[gid] = s
+	}
+	return s
+}
+
+// goroutineID parses it out of runtime.Stack since the runtime does
+// not expose one directly. Only used for the detector's bookkeeping,
+// never for control flow correctness.
+func goroutineID() int64 {
+	var buf [64]byte
+	n := runtime.Stack(buf[:], false)
+	b := buf[:n]
+	// "goroutine 123 [running]: ..."
+	const prefix = "goroutine "
+	if !bytes.HasPrefix(b, []byte(prefix)) {
+		return 0
+	}
+	b = b[len(prefix):]
+	var id int64
+	for _, c := range b {
+		if c < '0' || c > '9' {
+			break
+		}
+		id = id*10 + int64(c-'0')
+	}
+	return id
+}
+
+// lockOrderGraph records, for every pair of locks (A, B), that B was
+// observed to be acquired while A was held. An edge A->B that would
+// complete a cycle means some other goroutine can observe B->...->A,
+// so acquiring both under that order can deadlock.
+type lockOrderGraph struct {
+	mu    sync.Mutex
+	edges map[lockID]map[lockID]edgeInfo
+}
+
+type edgeInfo struct {
+	fromStack []byte // stack holding A when B was acquired
+	toStack   []byte // stack acquiring B
+}
+
+var orderGraph = &lockOrderGraph{edges: map[lockID]map[lockID]edgeInfo{}}
+
+// recordAndCheck adds edges "held -> acquiring" for every lock
+// currently held by this goroutine, then checks the whole graph for a
+// cycle. On a cycle it panics with the stacks recorded for each edge
+// along the cycle.
+func (g *lockOrderGraph) recordAndCheck(held []heldLock, acquiring lockID, acquiringStack []byte) {
+	if len(held) == 0 {
+		return
+	}
+
+	g.mu.Lock()
+	for _, h := range held {
+		if h.id == acquiring {
+			continue
+		}
+		m := g.edges[h.id]
+		if m == nil {
+			m = map[lockID]edgeInfo{}
+			g.edges[h.id] = m
+		}
+		if _, ok := m[acquiring]; !ok {
+			m[acquiring] = edgeInfo{fromStack: h.stack, toStack: acquiringStack}
+		}
+	}
+	cycle := g.findCycle()
+	g.mu.Unlock()
+
+	if cycle != nil {
+		panic(formatCycle(cycle, g))
+	}
+}
+
+// color marks nodes during the three-color DFS: white (unvisited),
+// gray (on the current DFS path), black (fully explored, no cycle
+// reachable from it).
+type color int
+
+const (
+	white color = iota
+	gray
+	black
+)
+
+// findCycle runs a DFS over the order graph looking for a back edge
+// into a gray node, which is exactly a cycle. Must be called with
+// g.mu held.
+func (g *lockOrderGraph) findCycle() []lockID {
+	colors := map[lockID]color{}
+	var path []lockID
+
+	var visit func(n lockID) []lockID
+	visit = func(n lockID) []lockID {
+		colors[n] = gray
+		path = append(path, n)
+
+		for next := range g.edges[n] {
+			switch colors[next] {
+			case white:
+				if cyc := visit(next); cyc != nil {
+					return cyc
+				}
+			case gray:
+				// back edge: path[i:] .. next is the cycle.
+				for i, p := range path {
+					if p == next {
+						cyc := append([]lockID{}, path[i:]...)
+						return append(cyc, next)
+					}
+				}
+			case black:
+				// already fully explored, no cycle through here
+			}
+		}
+
+		path = path[:len(path)-1]
+		colors[n] = black
+		return nil
+	}
+
+	for n := range g.edges {
+		if colors[n] == white {
+			if cyc := visit(n); cyc != nil {
+				return cyc
+			}
+		}
+	}
+	return nil
+}
+
+func formatCycle(cycle []lockID, g *lockOrderGraph) string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "syncx: lock order cycle detected (%d edges):\n", len(cycle)-1)
+	for i := 0; i+1 < len(cycle); i++ {
+		from, to := cycle[i], cycle[i+1]
+		info := g.edges[from][to]
+		fmt.Fprintf(&buf, "\n--- lock %d acquired while holding lock %d ---\n", to, from)
+		fmt.Fprintf(&buf, "lock %d was taken at:\n%s\n", from, info.fromStack)
+		fmt.Fprintf(&buf, "lock %d was then taken at:\n%s\n", to, info.toStack)
+	}
+	return buf.String()
+}
+
+func captureStack() []byte {
+	buf := make([]byte, 4096)
+	for {
+		n := runtime.Stack(buf, false)
+		if n < len(buf) {
+			return buf[:n]
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
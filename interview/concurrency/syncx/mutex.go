@@ -0,0 +1,131 @@
+//go:build !syncx_nodetect
+
+package syncx
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+// Mutex is a drop-in replacement for sync.Mutex that detects the
+// deadlock patterns from test1 (reentrant Lock) and test5 (locking a
+// copy of an already-locked mutex), plus cross-mutex lock-ordering
+// cycles, at the moment they happen instead of as a silent hang.
+//
+// The zero value is an unlocked Mutex with detection fully enabled;
+// set Opts before the first Lock to tune it.
+type Mutex struct {
+	noCopy noCopy
+	Opts   Opts
+
+	mu   sync.Mutex
+	once sync.Once
+	id   lockID
+
+	// selfAddr is the address of this Mutex recorded at first use,
+	// so a later Lock on a byte-copy of it (different address, same
+	// field values) can be told apart from a legitimate re-Lock.
+	selfAddr uintptr
+
+	holderMu    sync.Mutex
+	holderStack []byte
+}
+
+func (m *Mutex) init() {
+	m.once.Do(func() {
+		m.id = newLockID()
+		if !m.Opts.DisableLockCopyCheck {
+			m.selfAddr = uintptr(unsafe.Pointer(m))
+		}
+	})
+}
+
+func (m *Mutex) checkNotCopied() {
+	if m.Opts.DisableLockCopyCheck || m.selfAddr == 0 {
+		return
+	}
+	if m.selfAddr != uintptr(unsafe.Pointer(m)) {
+		panic("syncx: Mutex used after being copied by value (see test5); pass it by pointer instead")
+	}
+}
+
+// Lock locks m, panicking if m is already held by the calling
+// goroutine, if m is a copy of an already-used Mutex, or if taking m
+// would close a cycle in the observed lock acquisition order.
+func (m *Mutex) Lock() {
+	m.init()
+	m.checkNotCopied()
+
+	s := currentState()
+	for _, h := range s.held {
+		if h.id == m.id {
+			panic("syncx: reentrant Lock on a Mutex already held by this goroutine (see test1)")
+		}
+	}
+
+	stack := captureStack()
+	if !m.Opts.DisableLockOrderDetection {
+		orderGraph.recordAndCheck(s.held, m.id, stack)
+	}
+
+	done := make(chan struct{})
+	go m.watchdog(done, stack)
+	m.mu.Lock()
+	close(done)
+
+	m.holderMu.Lock()
+	m.holderStack = stack
+	m.holderMu.Unlock()
+
+	s.held = append(s.held, heldLock{id: m.id, stack: stack})
+}
+
+// Unlock unlocks m. As with sync.Mutex it is a runtime error to
+// Unlock an unlocked Mutex.
+func (m *Mutex) Unlock() {
+	m.holderMu.Lock()
+	m.holderStack = nil
+	m.holderMu.Unlock()
+
+	s := currentState()
+	for i, h := range s.held {
+		if h.id == m.id {
+			s.held = append(s.held[:i], s.held[i+1:]...)
+			break
+		}
+	}
+
+	m.mu.Unlock()
+}
+
+// watchdog dumps all goroutines and the current holder's acquisition
+// stack if Lock has not completed within Opts.deadlockTimeout. It
+// exits as soon as done is closed, i.e. once Lock succeeds.
+func (m *Mutex) watchdog(done <-chan struct{}, acquiringStack []byte) {
+	timer := time.NewTimer(m.Opts.deadlockTimeout())
+	defer timer.Stop()
+
+	select {
+	case <-done:
+		return
+	case <-timer.C:
+	}
+
+	m.holderMu.Lock()
+	holder := m.holderStack
+	m.holderMu.Unlock()
+
+	buf := make([]byte, 1<<20)
+	n := runtime.Stack(buf, true)
+
+	fmt.Fprintf(os.Stderr, "syncx: Lock has been blocked for over %s\n", m.Opts.deadlockTimeout())
+	fmt.Fprintf(os.Stderr, "acquiring goroutine is waiting at:\n%s\n", acquiringStack)
+	if holder != nil {
+		fmt.Fprintf(os.Stderr, "lock is currently held, acquired at:\n%s\n", holder)
+	}
+	fmt.Fprintf(os.Stderr, "all goroutines:\n%s\n", buf[:n])
+}
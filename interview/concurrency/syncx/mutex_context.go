@@ -0,0 +1,99 @@
+//go:build !syncx_nodetect
+
+package syncx
+
+import "context"
+
+// TryLock acquires m without blocking, returning false if it is
+// already held. Like Lock, it panics on reentrancy, a copied mutex,
+// or a lock-order cycle.
+func (m *Mutex) TryLock() bool {
+	m.init()
+	m.checkNotCopied()
+
+	s := currentState()
+	for _, h := range s.held {
+		if h.id == m.id {
+			panic("syncx: reentrant TryLock on a Mutex already held by this goroutine (see test1)")
+		}
+	}
+
+	stack := captureStack()
+	if !m.Opts.DisableLockOrderDetection {
+		orderGraph.recordAndCheck(s.held, m.id, stack)
+	}
+
+	if !m.mu.TryLock() {
+		return false
+	}
+
+	m.holderMu.Lock()
+	m.holderStack = stack
+	m.holderMu.Unlock()
+	s.held = append(s.held, heldLock{id: m.id, stack: stack})
+	return true
+}
+
+// LockContext acquires m, returning ctx.Err() if ctx is done before
+// the lock becomes available. It never busy-waits: the acquiring
+// goroutine races a helper goroutine's raw acquire of the underlying
+// mutex against ctx.Done(), and hands ownership across a one-shot
+// channel.
+//
+// An earlier version had the helper call m.Lock() itself, which
+// attributes the held-lock entry to whichever goroutine calls it - so
+// the helper, not the caller, ended up owning the detector's
+// bookkeeping. Once the helper exited after a successful handoff, the
+// caller's Unlock couldn't find m.id under its own goroutine state,
+// and if the helper's goroutine ID was later reused, that new
+// goroutine could trip a spurious "reentrant Lock" panic. The
+// reentrancy check, lock-order check, and held-lock bookkeeping all
+// run here instead, on the calling goroutine, both before the helper
+// is spawned and after a successful handoff; the helper itself only
+// ever touches the raw m.mu, never the detector state, so it has
+// nothing to mis-attribute.
+func (m *Mutex) LockContext(ctx context.Context) error {
+	m.init()
+	m.checkNotCopied()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s := currentState()
+	for _, h := range s.held {
+		if h.id == m.id {
+			panic("syncx: reentrant LockContext on a Mutex already held by this goroutine (see test1)")
+		}
+	}
+
+	stack := captureStack()
+	if !m.Opts.DisableLockOrderDetection {
+		orderGraph.recordAndCheck(s.held, m.id, stack)
+	}
+
+	handoff := make(chan struct{})
+	abort := make(chan struct{})
+
+	go func() {
+		m.mu.Lock() // raw acquire only: bookkeeping below is attributed to the caller, not this goroutine
+		select {
+		case handoff <- struct{}{}:
+			// caller takes ownership below.
+		case <-abort:
+			m.mu.Unlock() // was never attributed to anyone's held list; plain release
+		}
+	}()
+
+	select {
+	case <-handoff:
+		m.holderMu.Lock()
+		m.holderStack = stack
+		m.holderMu.Unlock()
+		s.held = append(s.held, heldLock{id: m.id, stack: stack})
+		return nil
+	case <-ctx.Done():
+		close(abort)
+		return ctx.Err()
+	}
+}
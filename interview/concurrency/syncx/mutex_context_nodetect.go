@@ -0,0 +1,39 @@
+//go:build syncx_nodetect
+
+package syncx
+
+import "context"
+
+// TryLock acquires m without blocking, returning false if it is
+// already held.
+func (m *Mutex) TryLock() bool { return m.mu.TryLock() }
+
+// LockContext acquires m, returning ctx.Err() if ctx is done before
+// the lock becomes available. See mutex_context.go for the detecting
+// variant's documentation of the handoff pattern; the logic is
+// identical, this build just skips the bookkeeping.
+func (m *Mutex) LockContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	handoff := make(chan struct{})
+	abort := make(chan struct{})
+
+	go func() {
+		m.Lock()
+		select {
+		case handoff <- struct{}{}:
+		case <-abort:
+			m.Unlock()
+		}
+	}()
+
+	select {
+	case <-handoff:
+		return nil
+	case <-ctx.Done():
+		close(abort)
+		return ctx.Err()
+	}
+}
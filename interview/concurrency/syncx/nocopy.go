@@ -0,0 +1,10 @@
+package syncx
+
+// noCopy lets `go vet`'s copylocks check flag accidental copies of a
+// Mutex/RWMutex (see sync.noCopy). It is a struct-embedding sentinel
+// only; the Lock/Unlock methods below exist purely so vet recognises
+// it as a Locker.
+type noCopy struct{}
+
+func (*noCopy) Lock()   {}
+func (*noCopy) Unlock() {}
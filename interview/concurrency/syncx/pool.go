@@ -0,0 +1,116 @@
+package syncx
+
+import "sync/atomic"
+
+// BoundedPoolConfig configures a BoundedPool.
+type BoundedPoolConfig[T any] struct {
+	// New creates a fresh item sized for hint when the pool has
+	// nothing free to hand out.
+	New func(hint int) T
+
+	// Capacity is the number of items each size-class shard retains
+	// before Put starts dropping instead of pooling. Unlike
+	// sync.Pool, items beyond this are simply discarded - they are
+	// never silently retained by a per-P cache.
+	Capacity int
+
+	// MaxItemCost is the largest cost Put will accept into the pool
+	// at all; anything above it is dropped. Zero means unbounded.
+	MaxItemCost int
+
+	// Shards lists size-class upper bounds in ascending order, e.g.
+	// []int{4 << 10, 1 << 20} for a small and a large shard. Get and
+	// Put route by the first bound >= the requested hint/cost, and
+	// anything above the last bound goes to the last shard, so a
+	// single oversized item can only ever occupy the top shard and
+	// can't evict what small callers rely on. A nil Shards means a
+	// single, unsharded pool.
+	Shards []int
+}
+
+// PoolStats reports BoundedPool hit/miss/drop counters since creation.
+type PoolStats struct {
+	Hits   int64
+	Misses int64
+	Drops  int64
+}
+
+type poolShard[T any] struct {
+	bound int
+	free  chan T
+}
+
+// BoundedPool is a sync.Pool alternative whose contents are not
+// silently reclaimed by the GC and whose size is bounded per size
+// class, so one goroutine occasionally requesting a huge item can't
+// inflate the steady-state memory every other caller pays for (see
+// test6: a single 256MiB buffer ends up cached on every P).
+type BoundedPool[T any] struct {
+	cfg    BoundedPoolConfig[T]
+	shards []*poolShard[T]
+	hits   int64
+	misses int64
+	drops  int64
+}
+
+// NewBoundedPool builds a BoundedPool from cfg. cfg.New must be set.
+func NewBoundedPool[T any](cfg BoundedPoolConfig[T]) *BoundedPool[T] {
+	bounds := cfg.Shards
+	if len(bounds) == 0 {
+		bounds = []int{cfg.MaxItemCost}
+	}
+	shards := make([]*poolShard[T], len(bounds))
+	for i, b := range bounds {
+		shards[i] = &poolShard[T]{bound: b, free: make(chan T, cfg.Capacity)}
+	}
+	return &BoundedPool[T]{cfg: cfg, shards: shards}
+}
+
+func (p *BoundedPool[T]) shardFor(n int) *poolShard[T] {
+	for _, s := range p.shards {
+		if n <= s.bound {
+			return s
+		}
+	}
+	return p.shards[len(p.shards)-1]
+}
+
+// Get returns a pooled item sized for hint if one is free, otherwise
+// it calls cfg.New(hint).
+func (p *BoundedPool[T]) Get(hint int) T {
+	s := p.shardFor(hint)
+	select {
+	case v := <-s.free:
+		atomic.AddInt64(&p.hits, 1)
+		return v
+	default:
+		atomic.AddInt64(&p.misses, 1)
+		return p.cfg.New(hint)
+	}
+}
+
+// Put returns v to the pool for reuse, tagged with its cost (e.g. a
+// buffer's capacity). Items costlier than MaxItemCost, or arriving
+// when their size class's free list is already full, are dropped
+// instead of retained.
+func (p *BoundedPool[T]) Put(v T, cost int) {
+	if p.cfg.MaxItemCost > 0 && cost > p.cfg.MaxItemCost {
+		atomic.AddInt64(&p.drops, 1)
+		return
+	}
+	s := p.shardFor(cost)
+	select {
+	case s.free <- v:
+	default:
+		atomic.AddInt64(&p.drops, 1)
+	}
+}
+
+// Stats returns a snapshot of the pool's hit/miss/drop counters.
+func (p *BoundedPool[T]) Stats() PoolStats {
+	return PoolStats{
+		Hits:   atomic.LoadInt64(&p.hits),
+		Misses: atomic.LoadInt64(&p.misses),
+		Drops:  atomic.LoadInt64(&p.drops),
+	}
+}
@@ -0,0 +1,166 @@
+//go:build !syncx_nodetect
+
+package syncx
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+// RWMutex is a drop-in replacement for sync.RWMutex that additionally
+// detects read-locking while a read lock is already held by the same
+// goroutine (test2: a reader calling into code that RLocks again
+// races the pending writer and can deadlock) and copied-mutex misuse
+// (test5), plus cross-mutex lock-ordering cycles.
+type RWMutex struct {
+	noCopy noCopy
+	Opts   Opts
+
+	mu   sync.RWMutex
+	once sync.Once
+	id   lockID
+
+	selfAddr uintptr
+
+	holderMu    sync.Mutex
+	holderStack []byte // writer holder only; readers are tracked per-goroutine
+}
+
+func (m *RWMutex) init() {
+	m.once.Do(func() {
+		m.id = newLockID()
+		if !m.Opts.DisableLockCopyCheck {
+			m.selfAddr = uintptr(unsafe.Pointer(m))
+		}
+	})
+}
+
+func (m *RWMutex) checkNotCopied() {
+	if m.Opts.DisableLockCopyCheck || m.selfAddr == 0 {
+		return
+	}
+	if m.selfAddr != uintptr(unsafe.Pointer(m)) {
+		panic("syncx: RWMutex used after being copied by value (see test5); pass it by pointer instead")
+	}
+}
+
+func (m *RWMutex) heldKind(s *goroutineState) (held bool) {
+	for _, h := range s.held {
+		if h.id == m.id {
+			return true
+		}
+	}
+	return false
+}
+
+// RLock read-locks m, panicking if the calling goroutine already
+// holds m for reading (see test2: A RLocks, then calls into B which
+// calls C which RLocks again - fine on its own, but racing a pending
+// Lock from another goroutine deadlocks because Go's RWMutex favours
+// writers once one is waiting).
+func (m *RWMutex) RLock() {
+	m.init()
+	m.checkNotCopied()
+
+	s := currentState()
+	if m.heldKind(s) {
+		panic("syncx: reentrant RLock on an RWMutex already held by this goroutine (see test2)")
+	}
+
+	stack := captureStack()
+	if !m.Opts.DisableLockOrderDetection {
+		orderGraph.recordAndCheck(s.held, m.id, stack)
+	}
+
+	done := make(chan struct{})
+	go m.watchdog(done, stack, "RLock")
+	m.mu.RLock()
+	close(done)
+
+	s.held = append(s.held, heldLock{id: m.id, stack: stack})
+}
+
+// RUnlock undoes a single RLock call, as with sync.RWMutex.
+func (m *RWMutex) RUnlock() {
+	s := currentState()
+	for i, h := range s.held {
+		if h.id == m.id {
+			s.held = append(s.held[:i], s.held[i+1:]...)
+			break
+		}
+	}
+	m.mu.RUnlock()
+}
+
+// Lock write-locks m, with the same reentrancy and copy checks as
+// Mutex.Lock.
+func (m *RWMutex) Lock() {
+	m.init()
+	m.checkNotCopied()
+
+	s := currentState()
+	if m.heldKind(s) {
+		panic("syncx: reentrant Lock on an RWMutex already held (for read or write) by this goroutine (see test1/test2)")
+	}
+
+	stack := captureStack()
+	if !m.Opts.DisableLockOrderDetection {
+		orderGraph.recordAndCheck(s.held, m.id, stack)
+	}
+
+	done := make(chan struct{})
+	go m.watchdog(done, stack, "Lock")
+	m.mu.Lock()
+	close(done)
+
+	m.holderMu.Lock()
+	m.holderStack = stack
+	m.holderMu.Unlock()
+
+	s.held = append(s.held, heldLock{id: m.id, stack: stack})
+}
+
+// Unlock undoes a single Lock call, as with sync.RWMutex.
+func (m *RWMutex) Unlock() {
+	m.holderMu.Lock()
+	m.holderStack = nil
+	m.holderMu.Unlock()
+
+	s := currentState()
+	for i, h := range s.held {
+		if h.id == m.id {
+			s.held = append(s.held[:i], s.held[i+1:]...)
+			break
+		}
+	}
+	m.mu.Unlock()
+}
+
+func (m *RWMutex) watchdog(done <-chan struct{}, acquiringStack []byte, op string) {
+	timer := time.NewTimer(m.Opts.deadlockTimeout())
+	defer timer.Stop()
+
+	select {
+	case <-done:
+		return
+	case <-timer.C:
+	}
+
+	m.holderMu.Lock()
+	holder := m.holderStack
+	m.holderMu.Unlock()
+
+	buf := make([]byte, 1<<20)
+	n := runtime.Stack(buf, true)
+
+	fmt.Fprintf(os.Stderr, "syncx: %s has been blocked for over %s\n", op, m.Opts.deadlockTimeout())
+	fmt.Fprintf(os.Stderr, "acquiring goroutine is waiting at:\n%s\n", acquiringStack)
+	if holder != nil {
+		fmt.Fprintf(os.Stderr, "writer currently holds the lock, acquired at:\n%s\n", holder)
+	}
+	fmt.Fprintf(os.Stderr, "all goroutines:\n%s\n", buf[:n])
+}
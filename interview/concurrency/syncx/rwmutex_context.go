@@ -0,0 +1,78 @@
+//go:build !syncx_nodetect
+
+package syncx
+
+import "context"
+
+// TryRLock acquires m for reading without blocking, returning false
+// if a writer currently holds it.
+func (m *RWMutex) TryRLock() bool {
+	m.init()
+	m.checkNotCopied()
+
+	s := currentState()
+	if m.heldKind(s) {
+		panic("syncx: reentrant TryRLock on an RWMutex already held by this goroutine (see test2)")
+	}
+
+	stack := captureStack()
+	if !m.Opts.DisableLockOrderDetection {
+		orderGraph.recordAndCheck(s.held, m.id, stack)
+	}
+
+	if !m.mu.TryRLock() {
+		return false
+	}
+
+	s.held = append(s.held, heldLock{id: m.id, stack: stack})
+	return true
+}
+
+// RLockContext acquires m for reading, returning ctx.Err() if ctx is
+// done before a read lock becomes available. See Mutex.LockContext for
+// the handoff pattern this builds on, and for why the reentrancy
+// check, lock-order check, and held-lock bookkeeping all run on the
+// calling goroutine rather than the helper that does the raw
+// m.mu.RLock(): RLock's held-lock entry is attributed to whichever
+// goroutine calls it, so a helper acquiring on the caller's behalf
+// would otherwise attribute it to the wrong one.
+func (m *RWMutex) RLockContext(ctx context.Context) error {
+	m.init()
+	m.checkNotCopied()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s := currentState()
+	if m.heldKind(s) {
+		panic("syncx: reentrant RLockContext on an RWMutex already held by this goroutine (see test2)")
+	}
+
+	stack := captureStack()
+	if !m.Opts.DisableLockOrderDetection {
+		orderGraph.recordAndCheck(s.held, m.id, stack)
+	}
+
+	handoff := make(chan struct{})
+	abort := make(chan struct{})
+
+	go func() {
+		m.mu.RLock() // raw acquire only: bookkeeping below is attributed to the caller, not this goroutine
+		select {
+		case handoff <- struct{}{}:
+			// caller takes ownership below.
+		case <-abort:
+			m.mu.RUnlock() // was never attributed to anyone's held list; plain release
+		}
+	}()
+
+	select {
+	case <-handoff:
+		s.held = append(s.held, heldLock{id: m.id, stack: stack})
+		return nil
+	case <-ctx.Done():
+		close(abort)
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,37 @@
+//go:build syncx_nodetect
+
+package syncx
+
+import "context"
+
+// TryRLock acquires m for reading without blocking, returning false
+// if a writer currently holds it.
+func (m *RWMutex) TryRLock() bool { return m.mu.TryRLock() }
+
+// RLockContext acquires m for reading, returning ctx.Err() if ctx is
+// done before a read lock becomes available.
+func (m *RWMutex) RLockContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	handoff := make(chan struct{})
+	abort := make(chan struct{})
+
+	go func() {
+		m.RLock()
+		select {
+		case handoff <- struct{}{}:
+		case <-abort:
+			m.RUnlock()
+		}
+	}()
+
+	select {
+	case <-handoff:
+		return nil
+	case <-ctx.Done():
+		close(abort)
+		return ctx.Err()
+	}
+}
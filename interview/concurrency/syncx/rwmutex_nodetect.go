@@ -0,0 +1,18 @@
+//go:build syncx_nodetect
+
+package syncx
+
+import "sync"
+
+// RWMutex is a zero-overhead alias for sync.RWMutex, selected by the
+// syncx_nodetect build tag. See rwmutex.go for the detecting variant.
+type RWMutex struct {
+	noCopy noCopy
+	Opts   Opts
+	mu     sync.RWMutex
+}
+
+func (m *RWMutex) Lock()    { m.mu.Lock() }
+func (m *RWMutex) Unlock()  { m.mu.Unlock() }
+func (m *RWMutex) RLock()   { m.mu.RLock() }
+func (m *RWMutex) RUnlock() { m.mu.RUnlock() }
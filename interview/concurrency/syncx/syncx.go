@@ -0,0 +1,41 @@
+// Package syncx provides drop-in replacements for sync.Mutex and
+// sync.RWMutex that turn the deadlock patterns explored in
+// interview/concurrency/main.go (test1, test2, test5 - reentrant
+// locking, read-locking while already read-locked, and copied-mutex
+// misuse) into a panic with the offending goroutine stacks instead of
+// a silent hang.
+//
+// Detection costs a goroutine-local lock stack plus a global
+// acquisition-order graph, so it can be compiled away entirely with
+// the syncx_nodetect build tag once a lock order has been audited.
+package syncx
+
+import "time"
+
+// DefaultDeadlockTimeout is used when Opts.DeadlockTimeout is zero.
+const DefaultDeadlockTimeout = 30 * time.Second
+
+// Opts configures the runtime behaviour of Mutex and RWMutex. The
+// zero value enables every check with DefaultDeadlockTimeout.
+type Opts struct {
+	// DisableLockOrderDetection skips recording edges in the global
+	// acquisition-order graph and its cycle check.
+	DisableLockOrderDetection bool
+
+	// DeadlockTimeout bounds how long Lock/RLock may block before the
+	// watchdog dumps all goroutines and the lock holder's acquisition
+	// stack. Zero means DefaultDeadlockTimeout.
+	DeadlockTimeout time.Duration
+
+	// DisableLockCopyCheck skips the pointer-identity check that
+	// catches a Mutex/RWMutex being copied after its first Lock (see
+	// test5's MyMutex).
+	DisableLockCopyCheck bool
+}
+
+func (o Opts) deadlockTimeout() time.Duration {
+	if o.DeadlockTimeout <= 0 {
+		return DefaultDeadlockTimeout
+	}
+	return o.DeadlockTimeout
+}
@@ -0,0 +1,146 @@
+// Package testsync provides test helpers for the goroutine-leak and
+// channel-deadlock anti-patterns demonstrated in
+// interview/concurrency/main.go (test7, test8, test10): instead of
+// running those examples and watching the process hang or grow
+// goroutines forever, tests built on this package turn either symptom
+// into a normal, actionable test failure.
+package testsync
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// settleWindow is how long AssertNoLeaks waits after the test body
+// returns before taking its final goroutine snapshot, so that
+// goroutines mid-teardown (e.g. unwinding a defer chain) aren't
+// reported as leaked.
+const settleWindow = 100 * time.Millisecond
+
+// AssertNoLeaks registers a t.Cleanup that fails t if any goroutine
+// alive when the cleanup runs was not alive when AssertNoLeaks was
+// called. Call it at the top of a test, before starting any
+// goroutines under test.
+func AssertNoLeaks(t TB) {
+	t.Helper()
+	before := snapshotGoroutines()
+
+	t.Cleanup(func() {
+		time.Sleep(settleWindow)
+		leaked := diffGoroutines(before, snapshotGoroutines())
+		if len(leaked) == 0 {
+			return
+		}
+
+		var b bytes.Buffer
+		fmt.Fprintf(&b, "%d new goroutine(s) still alive after the test finished:\n", len(leaked))
+		for _, g := range leaked {
+			b.WriteString("\n")
+			b.WriteString(g)
+		}
+		t.Errorf("%s", b.String())
+	})
+}
+
+// snapshotGoroutines maps goroutine ID to that goroutine's full stack
+// trace, for every goroutine currently running except ones that are
+// part of the Go runtime or the testing framework itself rather than
+// something a test started.
+func snapshotGoroutines() map[int64]string {
+	buf := make([]byte, 1<<16)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+
+	out := map[int64]string{}
+	for _, g := range splitGoroutines(string(buf)) {
+		if isFrameworkGoroutine(g) {
+			continue
+		}
+		if id, ok := goroutineID(g); ok {
+			out[id] = g
+		}
+	}
+	return out
+}
+
+// diffGoroutines returns the stacks present in after but not before,
+// i.e. goroutines that came into existence since the snapshot in
+// before was taken.
+func diffGoroutines(before, after map[int64]string) []string {
+	var leaked []string
+	for id, stack := range after {
+		if _, ok := before[id]; !ok {
+			leaked = append(leaked, stack)
+		}
+	}
+	return leaked
+}
+
+// splitGoroutines breaks a runtime.Stack(..., true) dump into its
+// per-goroutine chunks, each starting with a "goroutine N [state]:"
+// header line.
+func splitGoroutines(dump string) []string {
+	var chunks []string
+	start := 0
+	for i := 1; i < len(dump); i++ {
+		if dump[i-1] == '\n' && i+len("goroutine ") <= len(dump) && dump[i:i+len("goroutine ")] == "goroutine " {
+			chunks = append(chunks, dump[start:i])
+			start = i
+		}
+	}
+	chunks = append(chunks, dump[start:])
+	return chunks
+}
+
+func goroutineID(chunk string) (int64, bool) {
+	const prefix = "goroutine "
+	if len(chunk) < len(prefix) || chunk[:len(prefix)] != prefix {
+		return 0, false
+	}
+	rest := chunk[len(prefix):]
+	end := 0
+	for end < len(rest) && rest[end] >= '0' && rest[end] <= '9' {
+		end++
+	}
+	if end == 0 {
+		return 0, false
+	}
+	id, err := strconv.ParseInt(rest[:end], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return id, true
+}
+
+// isFrameworkGoroutine reports whether a goroutine stack belongs to
+// the Go runtime or to `go test`'s own machinery, neither of which a
+// test can be blamed for leaking.
+func isFrameworkGoroutine(chunk string) bool {
+	for _, marker := range []string{
+		"runtime.gcBgMarkWorker",
+		"runtime.bgsweep",
+		"runtime.bgscavenge",
+		"runtime.forcegchelper",
+		"runtime.sysmon",
+		"signal.signal_recv",
+		"os/signal.signal_recv",
+		"testing.RunTests",
+		"testing.(*M).Run",
+		"testing.runFuzzing",
+	} {
+		if strings.Contains(chunk, marker) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,14 @@
+package testsync
+
+// TB is the subset of testing.TB that AssertNoLeaks and
+// WithDeadlockWatchdog need. *testing.T satisfies it without any
+// wrapping. It exists as a seam so a test that wants to verify the
+// detectors themselves - without letting a failure they deliberately
+// provoke propagate to the real *testing.T, which Go's testing
+// package does regardless of how t.Run's return value is used - can
+// pass a recording stub instead.
+type TB interface {
+	Helper()
+	Cleanup(func())
+	Errorf(format string, args ...interface{})
+}
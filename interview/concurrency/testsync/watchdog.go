@@ -0,0 +1,40 @@
+package testsync
+
+import (
+	"runtime"
+	"time"
+)
+
+// WithDeadlockWatchdog spawns a goroutine that, after d, dumps every
+// goroutine's stack and marks t failed, so a test stuck on a channel
+// deadlock (such as an unmatched send) is reported together with the
+// stacks that show where it is stuck. It does not end or unblock the
+// stuck test. Call the returned stop func (typically via defer) once the
+// code under test has finished, so a slow-but-not-deadlocked test
+// doesn't trip the watchdog after the fact.
+//
+// The watchdog reports failure with t.Error rather than t.Fatal: the
+// testing package requires FailNow (which Fatal calls) to run on the
+// goroutine executing the test function, and by definition that
+// goroutine is the one stuck - it can't be made to stop from here.
+func WithDeadlockWatchdog(t TB, d time.Duration) (stop func()) {
+	t.Helper()
+	done := make(chan struct{})
+
+	go func() {
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+
+		select {
+		case <-done:
+			return
+		case <-timer.C:
+		}
+
+		buf := make([]byte, 1<<20)
+		n := runtime.Stack(buf, true)
+		t.Errorf("test still running after %s, dumping all goroutines:\n%s", d, buf[:n])
+	}()
+
+	return func() { close(done) }
+}